@@ -1,69 +1,355 @@
 package main
 
 import (
+	"bufio"
+	"crypto/ed25519"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
 
 	"multiNetworkWalletGenerator/utils"
+	"multiNetworkWalletGenerator/utils/scanner"
+	"multiNetworkWalletGenerator/utils/slip10"
+	"multiNetworkWalletGenerator/wallets/ledger"
 
 	"github.com/tyler-smith/go-bip32"
 	"github.com/tyler-smith/go-bip39"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "decrypt":
+			if err := runDecrypt(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "scan":
+			if err := runScan(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+	runGenerate()
+}
+
+// runGenerate implements the default `wallets` behavior: derive wallets and
+// save them to the output file.
+func runGenerate() {
 	// Command-line arguments
 	mnemonic := flag.String("mnemonic", "", "Mnemonic to be passed as --mnemonic=''")
 	network := flag.String("network", "", "Network to be passed as --network=''")
 	walletCount := flag.Int("count", 1, "Number of wallets to generate")
 	outputFile := flag.String("output", "wallets.json", "Output file to save the wallets data")
+	source := flag.String("source", "mnemonic", "Key source to be passed as --source='mnemonic'|'ledger'")
+	encrypt := flag.Bool("encrypt", false, "Encrypt the output as Web3-secret-storage keystore files")
+	keystorePassphrase := flag.String("keystore-passphrase", "", "Passphrase used to encrypt the output, or set WALLET_PASSPHRASE")
+	mnemonicPassphrase := flag.String("passphrase", "", "BIP-39 passphrase for the mnemonic (the 25th word); read from stdin if not set")
+	derivationPath := flag.String("path", "", "Derivation path, e.g. m/44'/60'/0'/0 (defaults to the standard BIP-44 path for the network)")
+	paper := flag.Bool("paper", false, "Emit a BIP-38 encrypted paper wallet (address + encrypted key QR codes) per wallet into --output")
+	paperPassphrase := flag.String("paper-passphrase", "", "Passphrase used to BIP-38 encrypt paper wallets, or set WALLET_PASSPHRASE")
 	flag.Parse()
 
-	// Generate the seed from the mnemonic
-	seed, err := generateSeed(*mnemonic)
+	var wallets []utils.Wallet
+	var err error
+
+	if *source == "ledger" {
+		wallets, err = generateWalletsFromLedger(*walletCount, *network)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else if utils.IsEd25519Network(*network) {
+		// Generate the seed from the mnemonic
+		seed, seedErr := generateSeed(*mnemonic, resolveMnemonicPassphrase(*mnemonicPassphrase))
+		if seedErr != nil {
+			log.Fatal(seedErr)
+		}
+
+		// Generate wallets via SLIP-0010 ed25519 derivation
+		wallets, err = generateEd25519Wallets(seed, *walletCount, *network)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		// Generate the seed from the mnemonic
+		seed, seedErr := generateSeed(*mnemonic, resolveMnemonicPassphrase(*mnemonicPassphrase))
+		if seedErr != nil {
+			log.Fatal(seedErr)
+		}
+
+		// Generate the master key from the seed
+		masterKey, keyErr := generateMasterKey(seed)
+		if keyErr != nil {
+			log.Fatal(keyErr)
+		}
+
+		// Determine the derivation path: either the one passed via --path, or
+		// the standard BIP-44 path for the network.
+		path, purpose, pathErr := resolveDerivationPath(*derivationPath, *network)
+		if pathErr != nil {
+			log.Fatal(pathErr)
+		}
+
+		// Generate the extended key for the specified path
+		extendedKey, extErr := deriveExtendedKey(masterKey, path)
+		if extErr != nil {
+			log.Fatal(extErr)
+		}
+
+		// Generate wallets
+		wallets, err = generateWallets(extendedKey, *walletCount, *network, purpose)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Save wallets to a file
+	if *paper {
+		err = writePaperWallets(wallets, *outputFile, resolvePassphrase(*paperPassphrase))
+	} else if *encrypt {
+		err = saveEncryptedWalletsToFile(wallets, *outputFile, resolvePassphrase(*keystorePassphrase))
+	} else {
+		err = saveWalletsToFile(wallets, *outputFile)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Generate the master key from the seed
-	masterKey, err := generateMasterKey(seed)
+	fmt.Printf("Wallets saved to %s\n", *outputFile)
+}
+
+// resolvePassphrase returns flagValue (the encryption passphrase supplied on
+// the command line) when set, falling back to the WALLET_PASSPHRASE
+// environment variable, and finally prompting on stdin.
+func resolvePassphrase(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if envValue := os.Getenv("WALLET_PASSPHRASE"); envValue != "" {
+		return envValue
+	}
+	fmt.Print("Enter passphrase: ")
+	passphrase, err := readLine()
 	if err != nil {
 		log.Fatal(err)
 	}
+	return passphrase
+}
 
-	// Generate the extended key for the specified network
-	coinType := utils.GetCoinType(*network)
-	extendedKey, err := deriveExtendedKey(masterKey, 44, coinType, 0, 0)
+// readLine reads a single line from stdin and trims its trailing newline.
+// fmt.Scanln stops at the first whitespace, which would silently truncate a
+// multi-word passphrase to its first token, so the passphrase prompts read a
+// whole line instead.
+func readLine() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("error reading from stdin: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// runDecrypt implements the `wallets decrypt` subcommand: it reads an
+// encrypted keystore file produced by saveEncryptedWalletsToFile and prints
+// the plaintext wallets.
+func runDecrypt(args []string) error {
+	fs := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	inputFile := fs.String("input", "wallets.json", "Encrypted keystore file to decrypt")
+	passphrase := fs.String("passphrase", "", "Passphrase used to decrypt the file, or set WALLET_PASSPHRASE")
+	fs.Parse(args)
+
+	data, err := os.ReadFile(*inputFile)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("error reading keystore file: %v", err)
+	}
+
+	var keys []json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("error parsing keystore file: %v", err)
+	}
+
+	pass := resolvePassphrase(*passphrase)
+
+	var wallets []utils.Wallet
+	for _, keyJSON := range keys {
+		var encryptedKey utils.EncryptedKey
+		if err := json.Unmarshal(keyJSON, &encryptedKey); err != nil {
+			return fmt.Errorf("error parsing encrypted key: %v", err)
+		}
+
+		privateKeyHex, err := utils.DecryptKey(keyJSON, pass)
+		if err != nil {
+			return fmt.Errorf("error decrypting key for %s: %v", encryptedKey.Address, err)
+		}
+
+		wallets = append(wallets, utils.Wallet{
+			PrivateKey: privateKeyHex,
+			Address:    encryptedKey.Address,
+		})
 	}
 
-	// Generate wallets
-	wallets, err := generateWallets(extendedKey, *walletCount, *network)
+	jsonData, err := json.MarshalIndent(wallets, "", "  ")
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("error marshaling decrypted wallets: %v", err)
 	}
+	fmt.Println(string(jsonData))
+	return nil
+}
 
-	// Save wallets to a file
-	err = saveWalletsToFile(wallets, *outputFile)
+// runScan implements the `wallets scan` subcommand: it walks accounts and
+// address indices under BIP-44, probing each address against rpc for
+// on-chain activity so funds derived under non-default indices can be
+// recovered.
+func runScan(args []string) error {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	mnemonic := fs.String("mnemonic", "", "Mnemonic to scan as --mnemonic=''")
+	mnemonicPassphrase := fs.String("passphrase", "", "BIP-39 passphrase for the mnemonic, if one was used to generate the wallets")
+	network := fs.String("network", "", "Network to scan as --network='EVM'|'BTC'|'TRX'")
+	rpcURL := fs.String("rpc", "", "RPC endpoint: JSON-RPC URL for EVM, Esplora base URL for BTC, node base URL for TRX")
+	purposeFlag := fs.Uint("purpose", 44, "BIP purpose to scan for BTC: 44 (legacy), 49 (P2SH-SegWit), or 84 (native SegWit)")
+	concurrency := fs.Int("concurrency", 8, "Number of addresses to probe concurrently")
+	maxAccounts := fs.Int("max-accounts", 20, "Maximum number of accounts to scan before stopping at the first fully unused one")
+	outputFile := fs.String("output", "", "If set, write only the active wallets (including private keys) to this file")
+	fs.Parse(args)
+
+	prober, err := scanProberForNetwork(*network)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
-	fmt.Printf("Wallets saved to %s\n", *outputFile)
+	seed, err := generateSeed(*mnemonic, *mnemonicPassphrase)
+	if err != nil {
+		return err
+	}
+	masterKey, err := generateMasterKey(seed)
+	if err != nil {
+		return err
+	}
+	coinType := utils.GetCoinType(*network)
+	purpose := uint32(*purposeFlag)
+
+	privateKeys := make(map[string]string)
+	var active []scanner.Result
+	for account := 0; account < *maxAccounts; account++ {
+		accountKey, err := deriveExtendedKey(masterKey, []uint32{
+			bip32.FirstHardenedChild + purpose,
+			bip32.FirstHardenedChild + coinType,
+			bip32.FirstHardenedChild + uint32(account),
+			0,
+		})
+		if err != nil {
+			return err
+		}
+
+		results, err := scanner.ScanBranch(prober, *rpcURL, 20, *concurrency, func(index uint32) (string, error) {
+			childKey := deriveChildKey(accountKey, index)
+			address := utils.AddressConversionForPurpose(childKey, *network, purpose)
+			privateKeys[address] = fmt.Sprintf("%x", childKey.Key)
+			return address, nil
+		})
+		if err != nil {
+			return fmt.Errorf("error scanning account %d: %v", account, err)
+		}
+		if len(results) == 0 {
+			break
+		}
+
+		for i := range results {
+			results[i].Account = account
+		}
+		active = append(active, results...)
+		fmt.Printf("account %d: %d active address(es)\n", account, len(results))
+	}
+
+	fmt.Printf("Scan complete: %d active address(es) found\n", len(active))
+	for _, r := range active {
+		fmt.Printf("  m/%d'/%d'/%d'/0/%d  %s  balance=%s\n", purpose, coinType, r.Account, r.Index, r.Address, r.Balance)
+	}
+
+	if *outputFile == "" {
+		return nil
+	}
+
+	var wallets []utils.Wallet
+	for _, r := range active {
+		wallets = append(wallets, utils.Wallet{
+			PrivateKey: privateKeys[r.Address],
+			Address:    r.Address,
+		})
+	}
+	return saveWalletsToFile(wallets, *outputFile)
 }
 
-// generateSeed creates a seed from the mnemonic with error checking.
-func generateSeed(mnemonic string) ([]byte, error) {
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+// scanProberForNetwork returns the scanner.Prober implementation for a
+// network supported by `wallets scan`.
+func scanProberForNetwork(network string) (scanner.Prober, error) {
+	switch network {
+	case "EVM":
+		return &scanner.EVMProber{}, nil
+	case "BTC":
+		return &scanner.BTCProber{}, nil
+	case "TRX":
+		return &scanner.TRXProber{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported scan network: %s", network)
+	}
+}
+
+// generateSeed creates a seed from the mnemonic and its BIP-39 passphrase
+// with error checking.
+func generateSeed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("error generating seed: %v", err)
 	}
 	return seed, nil
 }
 
+// resolveMnemonicPassphrase returns the BIP-39 passphrase supplied via
+// --passphrase, falling back to prompting on stdin. An empty passphrase is a
+// valid choice (the standard, unprotected BIP-39 seed).
+func resolveMnemonicPassphrase(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	fmt.Print("Enter BIP-39 passphrase (leave empty for none): ")
+	passphrase, err := readLine()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return passphrase
+}
+
+// resolveDerivationPath returns the derivation indices and BIP purpose
+// (44/49/84) to use: either parsed from the --path flag, or the standard
+// BIP-44 path for the network.
+func resolveDerivationPath(path, network string) ([]uint32, uint32, error) {
+	if path == "" {
+		coinType := utils.GetCoinType(network)
+		return []uint32{
+			bip32.FirstHardenedChild + 44,
+			bip32.FirstHardenedChild + coinType,
+			bip32.FirstHardenedChild + 0,
+			0,
+		}, 44, nil
+	}
+
+	indices, err := utils.ParseDerivationPath(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(indices) == 0 {
+		return nil, 0, fmt.Errorf("derivation path %q has no segments", path)
+	}
+
+	purpose := indices[0] &^ bip32.FirstHardenedChild
+	return indices, purpose, nil
+}
+
 // generateMasterKey creates a BIP32 master key from the seed.
 func generateMasterKey(seed []byte) (*bip32.Key, error) {
 	masterKey, err := bip32.NewMasterKey(seed)
@@ -73,14 +359,9 @@ func generateMasterKey(seed []byte) (*bip32.Key, error) {
 	return masterKey, nil
 }
 
-// deriveExtendedKey derives an extended key from the master key based on the BIP44 path.
-func deriveExtendedKey(masterKey *bip32.Key, purpose, coinType, account, change uint32) (*bip32.Key, error) {
-	path := []uint32{
-		bip32.FirstHardenedChild + purpose,  // Purpose: BIP-44
-		bip32.FirstHardenedChild + coinType, // Coin type: Bitcoin/Ethereum/Tron, etc.
-		bip32.FirstHardenedChild + account,  // Account: 0
-		change,                              // Change: 0 (external addresses)
-	}
+// deriveExtendedKey derives an extended key from the master key by walking
+// the given derivation path.
+func deriveExtendedKey(masterKey *bip32.Key, path []uint32) (*bip32.Key, error) {
 	extendedKey := masterKey
 	for _, index := range path {
 		extendedKey = deriveChildKey(extendedKey, index)
@@ -89,14 +370,16 @@ func deriveExtendedKey(masterKey *bip32.Key, purpose, coinType, account, change
 }
 
 // generateWallets generates a list of wallets based on the extended key.
-func generateWallets(extendedKey *bip32.Key, walletCount int, network string) ([]utils.Wallet, error) {
+// purpose selects the Bitcoin address format (44 for legacy, 49 for
+// P2SH-P2WPKH, 84 for native SegWit); it is ignored for other networks.
+func generateWallets(extendedKey *bip32.Key, walletCount int, network string, purpose uint32) ([]utils.Wallet, error) {
 	var wallets []utils.Wallet
 
 	for i := 0; i < walletCount; i++ {
 		childKey := deriveChildKey(extendedKey, uint32(i))
 
 		// Convert to address
-		address := utils.AddressConversion(childKey, network)
+		address := utils.AddressConversionForPurpose(childKey, network, purpose)
 
 		wallets = append(wallets, utils.Wallet{
 			PrivateKey: fmt.Sprintf("%x", childKey.Key),
@@ -108,6 +391,160 @@ func generateWallets(extendedKey *bip32.Key, walletCount int, network string) ([
 	return wallets, nil
 }
 
+// generateEd25519Wallets generates a list of wallets for the SLIP-0010
+// ed25519 networks (SOL, SUI, APT), each of which uses a fully hardened
+// derivation path rather than BIP-32 secp256k1.
+func generateEd25519Wallets(seed []byte, walletCount int, network string) ([]utils.Wallet, error) {
+	masterKey, err := slip10.NewMasterKey(seed)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving slip10 master key: %v", err)
+	}
+
+	var wallets []utils.Wallet
+	for i := 0; i < walletCount; i++ {
+		path, err := ed25519DerivationPath(network, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+
+		childKey, err := slip10.DerivePath(masterKey, path)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving slip10 child key: %v", err)
+		}
+
+		privateKey := ed25519.NewKeyFromSeed(childKey.Key[:])
+		publicKey := privateKey.Public().(ed25519.PublicKey)
+		address := utils.AddressConversionEd25519(publicKey, network)
+
+		wallets = append(wallets, utils.Wallet{
+			PrivateKey: fmt.Sprintf("%x", childKey.Key),
+			PublicKey:  fmt.Sprintf("%x", publicKey),
+			Address:    address,
+		})
+	}
+
+	return wallets, nil
+}
+
+// ed25519DerivationPath returns the fully hardened BIP-44 path for a
+// SLIP-0010 ed25519 network at account index i: m/44'/coin'/i'/0' for
+// Solana, and an additional address-index level (m/44'/coin'/i'/0'/0')
+// for Sui and Aptos. Every index is hardened, as slip10.NewChildKey
+// requires for ed25519.
+func ed25519DerivationPath(network string, i uint32) ([]uint32, error) {
+	coinType := utils.GetCoinType(network)
+	base := []uint32{44 | slip10.HardenedOffset, coinType | slip10.HardenedOffset, i | slip10.HardenedOffset, 0 | slip10.HardenedOffset}
+
+	switch network {
+	case "SOL":
+		return base, nil
+	case "SUI", "APT":
+		return append(base, 0|slip10.HardenedOffset), nil
+	default:
+		return nil, fmt.Errorf("unsupported ed25519 network: %s", network)
+	}
+}
+
+// generateWalletsFromLedger enumerates addresses from a connected Ledger
+// device instead of deriving them from a mnemonic. Only the address is
+// recorded; the private key never leaves the device, and the public key the
+// device returns is not written to the wallet.
+func generateWalletsFromLedger(walletCount int, network string) ([]utils.Wallet, error) {
+	signer, err := ledger.New()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to ledger: %v", err)
+	}
+	defer signer.Close()
+
+	coinType := utils.GetCoinType(network)
+
+	var wallets []utils.Wallet
+	for i := 0; i < walletCount; i++ {
+		path := []uint32{
+			bip32.FirstHardenedChild + 44,
+			bip32.FirstHardenedChild + coinType,
+			bip32.FirstHardenedChild + 0,
+			0,
+			uint32(i),
+		}
+		address, err := signer.DeriveAddress(path, network)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving ledger address: %v", err)
+		}
+		wallets = append(wallets, utils.Wallet{Address: address})
+	}
+
+	return wallets, nil
+}
+
+// saveEncryptedWalletsToFile encrypts each wallet's private key into a
+// Web3-secret-storage keystore entry and writes the resulting array to
+// outputFile. Wallets without a private key (e.g. from a hardware signer)
+// are skipped.
+func saveEncryptedWalletsToFile(wallets []utils.Wallet, outputFile, passphrase string) error {
+	var encryptedKeys []json.RawMessage
+	for _, wallet := range wallets {
+		if wallet.PrivateKey == "" {
+			continue
+		}
+		keyJSON, err := utils.EncryptKey(wallet.PrivateKey, wallet.Address, passphrase)
+		if err != nil {
+			return fmt.Errorf("error encrypting wallet %s: %v", wallet.Address, err)
+		}
+		encryptedKeys = append(encryptedKeys, keyJSON)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	jsonData, err := json.MarshalIndent(encryptedKeys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling keystore data to JSON: %v", err)
+	}
+
+	if _, err := file.Write(jsonData); err != nil {
+		return fmt.Errorf("error writing to file: %v", err)
+	}
+
+	return nil
+}
+
+// writePaperWallets emits a printable artifact for each wallet into the
+// outputDir directory: a QR code of the address, and a QR code of the
+// private key BIP-38-encrypted with passphrase. Wallets without a private
+// key (e.g. from a hardware signer) get only the address QR code.
+func writePaperWallets(wallets []utils.Wallet, outputDir, passphrase string) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("error creating output directory: %v", err)
+	}
+
+	for i, wallet := range wallets {
+		addressPath := fmt.Sprintf("%s/wallet-%d-address.png", outputDir, i)
+		if err := utils.WriteQRCodePNG(wallet.Address, addressPath); err != nil {
+			return err
+		}
+
+		if wallet.PrivateKey == "" {
+			continue
+		}
+
+		encryptedKey, err := utils.EncryptBIP38(wallet.PrivateKey, wallet.Address, passphrase)
+		if err != nil {
+			return fmt.Errorf("error bip38-encrypting wallet %s: %v", wallet.Address, err)
+		}
+
+		keyPath := fmt.Sprintf("%s/wallet-%d-key.png", outputDir, i)
+		if err := utils.WriteQRCodePNG(encryptedKey, keyPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // deriveChildKey derives a child key for a given index.
 func deriveChildKey(extendedKey *bip32.Key, index uint32) *bip32.Key {
 	childKey, err := extendedKey.NewChildKey(index)
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/scrypt"
+)
+
+// BIP-38 non-EC-multiply constants.
+const (
+	bip38Prefix          = "0142"
+	bip38FlagCompressed  = 0xE0
+	bip38ScryptN         = 16384
+	bip38ScryptR         = 8
+	bip38ScryptP         = 8
+	bip38ScryptDKLen     = 64
+	bip38AddressHashSize = 4
+)
+
+// EncryptBIP38 encrypts a hex-encoded private key with passphrase using
+// BIP-38 non-EC-multiply encryption, salted with the hash of address so the
+// encrypted key can only be decrypted if the resulting address is known to
+// match. The address must already be network-appropriate (e.g. a Bitcoin,
+// Ethereum, or Tron address derived from the same key).
+func EncryptBIP38(privateKeyHex, address, passphrase string) (string, error) {
+	privateKey, err := hex.DecodeString(privateKeyHex)
+	if err != nil {
+		return "", fmt.Errorf("error decoding private key: %v", err)
+	}
+	if len(privateKey) != 32 {
+		return "", fmt.Errorf("private key must be 32 bytes, got %d", len(privateKey))
+	}
+
+	addressHash := bip38AddressHash(address)
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), addressHash, bip38ScryptN, bip38ScryptR, bip38ScryptP, bip38ScryptDKLen)
+	if err != nil {
+		return "", fmt.Errorf("error deriving scrypt key: %v", err)
+	}
+	derivedHalf1, derivedHalf2 := derivedKey[:32], derivedKey[32:]
+
+	block, err := aes.NewCipher(derivedHalf2)
+	if err != nil {
+		return "", fmt.Errorf("error creating aes cipher: %v", err)
+	}
+
+	encryptedHalf1 := make([]byte, 16)
+	block.Encrypt(encryptedHalf1, xorBytes(privateKey[:16], derivedHalf1[:16]))
+
+	encryptedHalf2 := make([]byte, 16)
+	block.Encrypt(encryptedHalf2, xorBytes(privateKey[16:], derivedHalf1[16:]))
+
+	prefix, err := hex.DecodeString(bip38Prefix)
+	if err != nil {
+		return "", fmt.Errorf("error decoding bip38 prefix: %v", err)
+	}
+
+	payload := append([]byte{}, prefix...)
+	payload = append(payload, bip38FlagCompressed)
+	payload = append(payload, addressHash...)
+	payload = append(payload, encryptedHalf1...)
+	payload = append(payload, encryptedHalf2...)
+
+	fullPayload := append(payload, computeChecksum(payload)...)
+	return base58.Encode(fullPayload), nil
+}
+
+// bip38AddressHash computes the 4-byte salt BIP-38 derives from the address:
+// the first 4 bytes of the double-SHA256 hash of the address string.
+func bip38AddressHash(address string) []byte {
+	firstHash := sha256.Sum256([]byte(address))
+	secondHash := sha256.Sum256(firstHash[:])
+	return secondHash[:bip38AddressHashSize]
+}
+
+// xorBytes XORs two equal-length byte slices and returns a new slice.
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
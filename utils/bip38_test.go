@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+// bip38Vector is one of the official BIP-38 non-EC-multiply test vectors:
+// a private key, the (compressed-format) address it derives to, the
+// passphrase used for encryption, and the expected ciphertext. EncryptBIP38
+// only implements the compressed-key flag, so only compressed vectors apply.
+type bip38Vector struct {
+	name       string
+	privateKey string
+	address    string
+	passphrase string
+	ciphertext string
+}
+
+// bip38Vectors are drawn from the official BIP-38 test vectors.
+var bip38Vectors = []bip38Vector{
+	{
+		name:       "compressed",
+		privateKey: "cbf4b9f70470856bb4f40f80b87edb90865997ffee6df315ab166d713af433a5",
+		address:    "164MQi977u9GUteHr4EPH27VkkdxmfCvGW",
+		passphrase: "TestingOneTwoThree",
+		ciphertext: "6PYNKZ1EAgYgmQfmNVamxyXVWHzK5s6DGhwP4J5o44cvXdoY7sRzhtpUeo",
+	},
+}
+
+func TestEncryptBIP38(t *testing.T) {
+	for _, v := range bip38Vectors {
+		t.Run(v.name, func(t *testing.T) {
+			got, err := EncryptBIP38(v.privateKey, v.address, v.passphrase)
+			if err != nil {
+				t.Fatalf("EncryptBIP38() error = %v", err)
+			}
+			if got != v.ciphertext {
+				t.Errorf("EncryptBIP38() = %s, want %s", got, v.ciphertext)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Web3-secret-storage scrypt parameters, matching go-ethereum's "standard" scrypt N.
+const (
+	scryptN      = 1 << 18 // 262144
+	scryptR      = 8
+	scryptP      = 1
+	scryptDKLen  = 32
+	keystoreVers = 3
+)
+
+// EncryptedKey is the Web3-secret-storage-format representation of a single
+// wallet's private key, compatible with `geth account import`.
+type EncryptedKey struct {
+	Address string       `json:"address"`
+	Crypto  cryptoParams `json:"crypto"`
+	ID      string       `json:"id"`
+	Version int          `json:"version"`
+}
+
+type cryptoParams struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    scryptParamsJSON `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// EncryptKey encrypts a hex-encoded private key into the Web3-secret-storage
+// JSON format used by go-ethereum's keystore.
+func EncryptKey(privateKeyHex, address, passphrase string) ([]byte, error) {
+	privateKey, err := hex.DecodeString(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %v", err)
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %v", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving scrypt key: %v", err)
+	}
+
+	iv := make([]byte, 16)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("error generating iv: %v", err)
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], privateKey, iv)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting private key: %v", err)
+	}
+
+	mac := crypto.Keccak256(append(derivedKey[16:32], cipherText...))
+
+	key := EncryptedKey{
+		Address: address,
+		Crypto: cryptoParams{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: scryptParamsJSON{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(mac),
+		},
+		ID:      uuid.New().String(),
+		Version: keystoreVers,
+	}
+
+	return json.MarshalIndent(key, "", "  ")
+}
+
+// DecryptKey recovers the hex-encoded private key from a Web3-secret-storage
+// JSON blob produced by EncryptKey (or by go-ethereum's keystore).
+func DecryptKey(keyJSON []byte, passphrase string) (string, error) {
+	var key EncryptedKey
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return "", fmt.Errorf("error parsing keystore json: %v", err)
+	}
+	if key.Crypto.Cipher != "aes-128-ctr" {
+		return "", fmt.Errorf("unsupported cipher: %s", key.Crypto.Cipher)
+	}
+	if key.Crypto.KDF != "scrypt" {
+		return "", fmt.Errorf("unsupported kdf: %s", key.Crypto.KDF)
+	}
+
+	salt, err := hex.DecodeString(key.Crypto.KDFParams.Salt)
+	if err != nil {
+		return "", fmt.Errorf("error decoding salt: %v", err)
+	}
+	iv, err := hex.DecodeString(key.Crypto.CipherParams.IV)
+	if err != nil {
+		return "", fmt.Errorf("error decoding iv: %v", err)
+	}
+	cipherText, err := hex.DecodeString(key.Crypto.CipherText)
+	if err != nil {
+		return "", fmt.Errorf("error decoding ciphertext: %v", err)
+	}
+	wantMAC, err := hex.DecodeString(key.Crypto.MAC)
+	if err != nil {
+		return "", fmt.Errorf("error decoding mac: %v", err)
+	}
+
+	params := key.Crypto.KDFParams
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return "", fmt.Errorf("error deriving scrypt key: %v", err)
+	}
+
+	gotMAC := crypto.Keccak256(append(derivedKey[16:32], cipherText...))
+	if !bytes.Equal(gotMAC, wantMAC) {
+		return "", fmt.Errorf("mac mismatch: incorrect passphrase")
+	}
+
+	privateKey, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting private key: %v", err)
+	}
+
+	return hex.EncodeToString(privateKey), nil
+}
+
+// aesCTRXOR encrypts (or, symmetrically, decrypts) data with AES-128 in CTR mode.
+func aesCTRXOR(key, data, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, iv)
+	out := make([]byte, len(data))
+	stream.XORKeyStream(out, data)
+	return out, nil
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"encoding/hex"
+	"testing"
+
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// TestEncryptKeyRoundTripsWithGethKeystore proves the Web3-secret-storage
+// JSON EncryptKey emits is the same format go-ethereum's own keystore
+// package reads, i.e. the file a user would load with `geth account import`.
+func TestEncryptKeyRoundTripsWithGethKeystore(t *testing.T) {
+	const (
+		privateKeyHex = "4646464646464646464646464646464646464646464646464646464646464646"
+		address       = "0x9858EfFD232B4033E47d90003D41EC34EcaEda1"
+		passphrase    = "correct horse battery staple"
+	)
+
+	keyJSON, err := EncryptKey(privateKeyHex, address, passphrase)
+	if err != nil {
+		t.Fatalf("EncryptKey() error = %v", err)
+	}
+
+	key, err := gethkeystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		t.Fatalf("geth keystore.DecryptKey() error = %v", err)
+	}
+
+	got := hex.EncodeToString(key.PrivateKey.D.Bytes())
+	if got != privateKeyHex {
+		t.Errorf("decrypted private key = %s, want %s", got, privateKeyHex)
+	}
+
+	if _, err := gethkeystore.DecryptKey(keyJSON, "wrong passphrase"); err == nil {
+		t.Error("geth keystore.DecryptKey() with the wrong passphrase should fail")
+	}
+}
+
+func TestDecryptKeyRoundTrip(t *testing.T) {
+	const (
+		privateKeyHex = "1111111111111111111111111111111111111111111111111111111111111111"
+		address       = "0x9858EfFD232B4033E47d90003D41EC34EcaEda1"
+		passphrase    = "hunter2"
+	)
+
+	keyJSON, err := EncryptKey(privateKeyHex, address, passphrase)
+	if err != nil {
+		t.Fatalf("EncryptKey() error = %v", err)
+	}
+
+	got, err := DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		t.Fatalf("DecryptKey() error = %v", err)
+	}
+	if got != privateKeyHex {
+		t.Errorf("DecryptKey() = %s, want %s", got, privateKeyHex)
+	}
+
+	if _, err := DecryptKey(keyJSON, "wrong passphrase"); err == nil {
+		t.Error("DecryptKey() with the wrong passphrase should fail")
+	}
+}
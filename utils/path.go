@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+// ParseDerivationPath parses a standard derivation path notation such as
+// "m/44'/60'/0'/0" or "m/84'/0'/0'/0" into a slice of BIP-32 indices with the
+// hardened bit set for every segment marked with a trailing ' (or h).
+func ParseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with \"m\"", path)
+	}
+
+	indices := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		hardened := false
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H") {
+			hardened = true
+			segment = segment[:len(segment)-1]
+		}
+
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: bad segment %q: %v", path, segment, err)
+		}
+
+		if hardened {
+			if uint32(index) >= bip32.FirstHardenedChild {
+				return nil, fmt.Errorf("invalid derivation path %q: index %q is too large to harden", path, segment)
+			}
+			indices = append(indices, bip32.FirstHardenedChild+uint32(index))
+		} else {
+			indices = append(indices, uint32(index))
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	h := bip32.FirstHardenedChild
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []uint32
+		wantErr bool
+	}{
+		{
+			name: "bip44 evm",
+			path: "m/44'/60'/0'/0",
+			want: []uint32{h + 44, h + 60, h, 0},
+		},
+		{
+			name: "bip49 p2sh-p2wpkh",
+			path: "m/49'/0'/0'/0",
+			want: []uint32{h + 49, h, h, 0},
+		},
+		{
+			name: "bip84 native segwit",
+			path: "m/84'/0'/0'/0",
+			want: []uint32{h + 84, h, h, 0},
+		},
+		{
+			name: "lowercase h hardening marker",
+			path: "m/44h/0h/0h/0",
+			want: []uint32{h + 44, h, h, 0},
+		},
+		{
+			name:    "missing m prefix",
+			path:    "44'/60'/0'/0",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric segment",
+			path:    "m/44'/abc'/0'/0",
+			wantErr: true,
+		},
+		{
+			name:    "hardened index too large wraps past the hardened bit",
+			path:    "m/2147483648'/0'/0'/0",
+			wantErr: true,
+		},
+		{
+			name: "hardened index at the hardened bit boundary",
+			path: "m/2147483647'/0'/0'/0",
+			want: []uint32{h + 2147483647, h, h, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDerivationPath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDerivationPath(%q) expected an error, got none", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDerivationPath(%q) error = %v", tt.path, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDerivationPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
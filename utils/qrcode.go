@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// qrPixelSize is the side length, in pixels, of the generated QR code PNGs.
+const qrPixelSize = 256
+
+// WriteQRCodePNG renders data as a QR code and writes it as a PNG to path.
+func WriteQRCodePNG(data, path string) error {
+	if err := qrcode.WriteFile(data, qrcode.Medium, qrPixelSize, path); err != nil {
+		return fmt.Errorf("error writing qr code %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// BTCProber probes a Bitcoin address via an Esplora-compatible REST API
+// (e.g. https://blockstream.info/api).
+type BTCProber struct {
+	Client *http.Client
+}
+
+type esploraAddressResponse struct {
+	ChainStats struct {
+		FundedTxoCount int   `json:"funded_txo_count"`
+		FundedTxoSum   int64 `json:"funded_txo_sum"`
+		SpentTxoCount  int   `json:"spent_txo_count"`
+		SpentTxoSum    int64 `json:"spent_txo_sum"`
+	} `json:"chain_stats"`
+	MempoolStats struct {
+		FundedTxoCount int `json:"funded_txo_count"`
+		SpentTxoCount  int `json:"spent_txo_count"`
+	} `json:"mempool_stats"`
+}
+
+// Probe calls GET /address/{address} and reports activity and balance in
+// satoshis.
+func (p *BTCProber) Probe(rpcURL, address string) (bool, string, error) {
+	client := p.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	resp, err := client.Get(fmt.Sprintf("%s/address/%s", rpcURL, address))
+	if err != nil {
+		return false, "", fmt.Errorf("error calling esplora: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, "", fmt.Errorf("esplora returned status %d", resp.StatusCode)
+	}
+
+	var parsed esploraAddressResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return false, "", fmt.Errorf("error decoding esplora response: %v", err)
+	}
+
+	used := parsed.ChainStats.FundedTxoCount > 0 || parsed.MempoolStats.FundedTxoCount > 0
+	balance := parsed.ChainStats.FundedTxoSum - parsed.ChainStats.SpentTxoSum
+
+	return used, fmt.Sprintf("%d", balance), nil
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBTCProberProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantUsed    bool
+		wantBalance string
+	}{
+		{
+			name:        "unused address",
+			body:        `{"chain_stats":{"funded_txo_count":0,"funded_txo_sum":0,"spent_txo_count":0,"spent_txo_sum":0},"mempool_stats":{"funded_txo_count":0,"spent_txo_count":0}}`,
+			wantUsed:    false,
+			wantBalance: "0",
+		},
+		{
+			name:        "funded with remaining balance",
+			body:        `{"chain_stats":{"funded_txo_count":2,"funded_txo_sum":150000,"spent_txo_count":1,"spent_txo_sum":50000},"mempool_stats":{"funded_txo_count":0,"spent_txo_count":0}}`,
+			wantUsed:    true,
+			wantBalance: "100000",
+		},
+		{
+			name:        "mempool-only activity",
+			body:        `{"chain_stats":{"funded_txo_count":0,"funded_txo_sum":0,"spent_txo_count":0,"spent_txo_sum":0},"mempool_stats":{"funded_txo_count":1,"spent_txo_count":0}}`,
+			wantUsed:    true,
+			wantBalance: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			prober := &BTCProber{}
+			used, balance, err := prober.Probe(server.URL, "bc1qexample")
+			if err != nil {
+				t.Fatalf("Probe() error = %v", err)
+			}
+			if used != tt.wantUsed {
+				t.Errorf("Probe() used = %v, want %v", used, tt.wantUsed)
+			}
+			if balance != tt.wantBalance {
+				t.Errorf("Probe() balance = %q, want %q", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestBTCProberProbeHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	prober := &BTCProber{}
+	if _, _, err := prober.Probe(server.URL, "bc1qexample"); err == nil {
+		t.Error("Probe() expected an error, got none")
+	}
+}
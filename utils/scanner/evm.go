@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+)
+
+// EVMProber probes an EVM-compatible address via JSON-RPC.
+type EVMProber struct {
+	Client *http.Client
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type jsonRPCResponse struct {
+	Result string `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Probe calls eth_getTransactionCount to detect activity and eth_getBalance
+// for the current balance, issuing both RPC calls concurrently.
+func (p *EVMProber) Probe(rpcURL, address string) (bool, string, error) {
+	var txCount, balanceHex string
+	var txCountErr, balanceErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		txCount, txCountErr = p.call(rpcURL, "eth_getTransactionCount", []interface{}{address, "latest"})
+	}()
+	go func() {
+		defer wg.Done()
+		balanceHex, balanceErr = p.call(rpcURL, "eth_getBalance", []interface{}{address, "latest"})
+	}()
+	wg.Wait()
+
+	if txCountErr != nil {
+		return false, "", txCountErr
+	}
+	if balanceErr != nil {
+		return false, "", balanceErr
+	}
+
+	used := txCount != "0x0"
+
+	balanceWei := new(big.Int)
+	if _, ok := balanceWei.SetString(trimHexPrefix(balanceHex), 16); !ok {
+		return false, "", fmt.Errorf("error parsing balance %q", balanceHex)
+	}
+	if balanceWei.Sign() != 0 {
+		used = true
+	}
+
+	return used, balanceWei.String(), nil
+}
+
+func (p *EVMProber) call(rpcURL, method string, params []interface{}) (string, error) {
+	client := p.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	body, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1})
+	if err != nil {
+		return "", fmt.Errorf("error encoding rpc request: %v", err)
+	}
+
+	resp, err := client.Post(rpcURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("error calling %s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return "", fmt.Errorf("error decoding %s response: %v", method, err)
+	}
+	if rpcResp.Error != nil {
+		return "", fmt.Errorf("rpc error calling %s: %s", method, rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result, nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEVMProberProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		txCount     string
+		balance     string
+		wantUsed    bool
+		wantBalance string
+	}{
+		{name: "unused empty address", txCount: "0x0", balance: "0x0", wantUsed: false, wantBalance: "0"},
+		{name: "used via tx count", txCount: "0x5", balance: "0x0", wantUsed: true, wantBalance: "0"},
+		{name: "used via nonzero balance only", txCount: "0x0", balance: "0xde0b6b3a7640000", wantUsed: true, wantBalance: "1000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req jsonRPCRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("decoding request: %v", err)
+				}
+				result := tt.txCount
+				if req.Method == "eth_getBalance" {
+					result = tt.balance
+				}
+				json.NewEncoder(w).Encode(jsonRPCResponse{Result: result})
+			}))
+			defer server.Close()
+
+			prober := &EVMProber{}
+			used, balance, err := prober.Probe(server.URL, "0xabc")
+			if err != nil {
+				t.Fatalf("Probe() error = %v", err)
+			}
+			if used != tt.wantUsed {
+				t.Errorf("Probe() used = %v, want %v", used, tt.wantUsed)
+			}
+			if balance != tt.wantBalance {
+				t.Errorf("Probe() balance = %q, want %q", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestEVMProberProbeRPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jsonRPCResponse{Error: &struct {
+			Message string `json:"message"`
+		}{Message: "boom"}})
+	}))
+	defer server.Close()
+
+	prober := &EVMProber{}
+	if _, _, err := prober.Probe(server.URL, "0xabc"); err == nil {
+		t.Error("Probe() expected an error, got none")
+	}
+}
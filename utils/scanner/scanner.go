@@ -0,0 +1,104 @@
+// Package scanner implements BIP-44 gap-limit address scanning against an
+// RPC endpoint to recover funds derived under non-default account or address
+// indices.
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultGapLimit is the number of consecutive unused addresses BIP-44
+// recommends scanning past before giving up on a branch.
+const defaultGapLimit = 20
+
+// defaultProbeTimeout bounds a single Probe call so a slow or unresponsive
+// RPC/Esplora/Tron endpoint can't hang a scan of thousands of addresses.
+const defaultProbeTimeout = 10 * time.Second
+
+// defaultHTTPClient is the fallback http.Client probers use when Client is
+// left unset; it's unexported so each prober's zero value still works but
+// nobody outside the package can mutate the shared default.
+var defaultHTTPClient = &http.Client{Timeout: defaultProbeTimeout}
+
+// Prober checks whether a single address has on-chain activity and reports
+// its current balance, for a specific network.
+type Prober interface {
+	// Probe reports whether address has ever been used and its current
+	// balance as a decimal string in the network's smallest unit (satoshis,
+	// wei, or sun).
+	Probe(rpcURL, address string) (used bool, balance string, err error)
+}
+
+// Result is an address found to have on-chain activity during a scan.
+type Result struct {
+	Account int
+	Index   uint32
+	Address string
+	Balance string
+}
+
+// ScanBranch derives addresses via next (called for index 0, 1, 2, ...) and
+// probes them concurrently in batches of size concurrency, stopping once
+// gapLimit consecutive addresses show no activity.
+func ScanBranch(prober Prober, rpcURL string, gapLimit, concurrency int, next func(index uint32) (string, error)) ([]Result, error) {
+	if gapLimit <= 0 {
+		gapLimit = defaultGapLimit
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	type probe struct {
+		index   uint32
+		address string
+		used    bool
+		balance string
+		err     error
+	}
+
+	var results []Result
+	consecutiveUnused := 0
+	index := uint32(0)
+
+	for consecutiveUnused < gapLimit {
+		batch := make([]probe, 0, concurrency)
+		for i := 0; i < concurrency; i++ {
+			address, err := next(index)
+			if err != nil {
+				return nil, fmt.Errorf("error deriving address at index %d: %v", index, err)
+			}
+			batch = append(batch, probe{index: index, address: address})
+			index++
+		}
+
+		var wg sync.WaitGroup
+		for i := range batch {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				batch[i].used, batch[i].balance, batch[i].err = prober.Probe(rpcURL, batch[i].address)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, p := range batch {
+			if p.err != nil {
+				return nil, fmt.Errorf("error probing %s: %v", p.address, p.err)
+			}
+			if p.used {
+				consecutiveUnused = 0
+				results = append(results, Result{Index: p.index, Address: p.address, Balance: p.balance})
+				continue
+			}
+			consecutiveUnused++
+			if consecutiveUnused >= gapLimit {
+				break
+			}
+		}
+	}
+
+	return results, nil
+}
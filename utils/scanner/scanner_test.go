@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"fmt"
+	"testing"
+)
+
+// fakeProber reports activity for a fixed set of indices, encoded in the
+// address string as next() produces it.
+type fakeProber struct {
+	usedAddresses map[string]bool
+}
+
+func (p *fakeProber) Probe(rpcURL, address string) (bool, string, error) {
+	if p.usedAddresses[address] {
+		return true, "1", nil
+	}
+	return false, "0", nil
+}
+
+func addressAt(index uint32) (string, error) {
+	return fmt.Sprintf("addr-%d", index), nil
+}
+
+func TestScanBranchStopsAtGapLimit(t *testing.T) {
+	prober := &fakeProber{usedAddresses: map[string]bool{"addr-0": true, "addr-3": true}}
+
+	results, err := ScanBranch(prober, "http://rpc.example", 2, 1, addressAt)
+	if err != nil {
+		t.Fatalf("ScanBranch() error = %v", err)
+	}
+
+	// addr-0 used, addr-1/addr-2 unused reach the gap limit of 2 before
+	// addr-3 (which is also used) would otherwise be reached.
+	want := []Result{{Index: 0, Address: "addr-0", Balance: "1"}}
+	if len(results) != len(want) || results[0] != want[0] {
+		t.Errorf("ScanBranch() = %v, want %v", results, want)
+	}
+}
+
+func TestScanBranchResetsGapOnActivity(t *testing.T) {
+	prober := &fakeProber{usedAddresses: map[string]bool{"addr-0": true, "addr-2": true}}
+
+	results, err := ScanBranch(prober, "http://rpc.example", 2, 1, addressAt)
+	if err != nil {
+		t.Fatalf("ScanBranch() error = %v", err)
+	}
+
+	want := []Result{
+		{Index: 0, Address: "addr-0", Balance: "1"},
+		{Index: 2, Address: "addr-2", Balance: "1"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("ScanBranch() = %v, want %v", results, want)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("ScanBranch()[%d] = %v, want %v", i, results[i], want[i])
+		}
+	}
+}
+
+func TestScanBranchPropagatesProbeError(t *testing.T) {
+	boom := fmt.Errorf("rpc unreachable")
+	prober := &erroringProber{err: boom}
+
+	if _, err := ScanBranch(prober, "http://rpc.example", 2, 1, addressAt); err == nil {
+		t.Error("ScanBranch() expected an error, got none")
+	}
+}
+
+type erroringProber struct {
+	err error
+}
+
+func (p *erroringProber) Probe(rpcURL, address string) (bool, string, error) {
+	return false, "", p.err
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TRXProber probes a Tron address via the node's wallet/getaccount HTTP API.
+type TRXProber struct {
+	Client *http.Client
+}
+
+type trxAccountResponse struct {
+	Address string `json:"address"`
+	Balance int64  `json:"balance"`
+}
+
+// Probe calls POST /wallet/getaccount and reports activity and balance in
+// sun (the smallest TRX unit). An account that has never been activated on
+// chain comes back as an empty JSON object.
+func (p *TRXProber) Probe(rpcURL, address string) (bool, string, error) {
+	client := p.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"address": address,
+		"visible": true,
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("error encoding request: %v", err)
+	}
+
+	resp, err := client.Post(fmt.Sprintf("%s/wallet/getaccount", rpcURL), "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return false, "", fmt.Errorf("error calling getaccount: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var account trxAccountResponse
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return false, "", fmt.Errorf("error decoding getaccount response: %v", err)
+	}
+
+	used := account.Address != ""
+	return used, fmt.Sprintf("%d", account.Balance), nil
+}
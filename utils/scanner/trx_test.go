@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTRXProberProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantUsed    bool
+		wantBalance string
+	}{
+		{name: "never activated", body: `{}`, wantUsed: false, wantBalance: "0"},
+		{name: "activated with balance", body: `{"address":"TExampleAddress","balance":1500000}`, wantUsed: true, wantBalance: "1500000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			prober := &TRXProber{}
+			used, balance, err := prober.Probe(server.URL, "TExampleAddress")
+			if err != nil {
+				t.Fatalf("Probe() error = %v", err)
+			}
+			if used != tt.wantUsed {
+				t.Errorf("Probe() used = %v, want %v", used, tt.wantUsed)
+			}
+			if balance != tt.wantBalance {
+				t.Errorf("Probe() balance = %q, want %q", balance, tt.wantBalance)
+			}
+		})
+	}
+}
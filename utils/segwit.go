@@ -0,0 +1,98 @@
+package utils
+
+import "strings"
+
+// bech32Charset is the character set used by the bech32 encoding (BIP-173).
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// bech32Polymod computes the bech32 checksum polymod over the given values.
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands the human-readable part for checksum purposes.
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		out = append(out, byte(c)>>5)
+	}
+	out = append(out, 0)
+	for _, c := range hrp {
+		out = append(out, byte(c)&31)
+	}
+	return out
+}
+
+// bech32CreateChecksum computes the 6-symbol checksum for hrp and data.
+func bech32CreateChecksum(hrp string, data []byte) []byte {
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	checksum := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		checksum[i] = byte((polymod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// bech32Encode encodes hrp and data (already 5-bit grouped) as a bech32 string.
+func bech32Encode(hrp string, data []byte) string {
+	combined := append(data, bech32CreateChecksum(hrp, data)...)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, b := range combined {
+		sb.WriteByte(bech32Charset[b])
+	}
+	return sb.String()
+}
+
+// convertBits regroups a byte slice from fromBits-bit groups to toBits-bit
+// groups, as required to turn a witness program into bech32 data.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1<<toBits) - 1
+	var out []byte
+
+	for _, value := range data {
+		acc = (acc << fromBits) | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte((acc>>bits)&maxv))
+		}
+	}
+
+	if pad && bits > 0 {
+		out = append(out, byte((acc<<(toBits-bits))&maxv))
+	}
+
+	return out, nil
+}
+
+// encodeSegwitAddress encodes a SegWit version-0 witness program (a 20-byte
+// pubkey hash for P2WPKH) as a bech32 address for the given human-readable
+// part (e.g. "bc" for Bitcoin mainnet).
+func encodeSegwitAddress(hrp string, witnessProgram []byte) (string, error) {
+	converted, err := convertBits(witnessProgram, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	data := append([]byte{0x00}, converted...)
+	return bech32Encode(hrp, data), nil
+}
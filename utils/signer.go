@@ -0,0 +1,72 @@
+package utils
+
+// Signer abstracts key derivation and transaction signing so that wallets can
+// be produced either from a local mnemonic or from an external device such as
+// a hardware wallet.
+type Signer interface {
+	// DeriveAddress returns the address for the given BIP-32 derivation path
+	// on the given network (e.g. "EVM", "BTC").
+	DeriveAddress(path []uint32, network string) (string, error)
+	// SignTx signs rawTx with the key at path and returns the signature.
+	SignTx(path []uint32, rawTx []byte, network string) ([]byte, error)
+}
+
+// MockSigner is an in-memory Signer used in tests and as a stand-in when no
+// hardware device is available. It deterministically derives an address from
+// the path so callers can exercise the Signer contract without real hardware.
+type MockSigner struct {
+	// Addresses, if set, maps a formatted path to a canned address. When a
+	// path is not present, DeriveAddress falls back to a deterministic value.
+	Addresses map[string]string
+	// Signatures, if set, maps a formatted path to a canned signature.
+	Signatures map[string][]byte
+}
+
+// DeriveAddress implements Signer.
+func (m *MockSigner) DeriveAddress(path []uint32, network string) (string, error) {
+	key := formatPath(path) + ":" + network
+	if m.Addresses != nil {
+		if addr, ok := m.Addresses[key]; ok {
+			return addr, nil
+		}
+	}
+	return "0xmock" + key, nil
+}
+
+// SignTx implements Signer.
+func (m *MockSigner) SignTx(path []uint32, rawTx []byte, network string) ([]byte, error) {
+	key := formatPath(path) + ":" + network
+	if m.Signatures != nil {
+		if sig, ok := m.Signatures[key]; ok {
+			return sig, nil
+		}
+	}
+	return append([]byte("mocksig:"+key+":"), rawTx...), nil
+}
+
+// formatPath renders a derivation path as "44/60/0/0/0" for use as a map key.
+func formatPath(path []uint32) string {
+	out := ""
+	for i, index := range path {
+		if i > 0 {
+			out += "/"
+		}
+		out += uitoa(index &^ (1 << 31))
+	}
+	return out
+}
+
+// uitoa converts a uint32 to its decimal string representation.
+func uitoa(v uint32) string {
+	if v == 0 {
+		return "0"
+	}
+	var buf [10]byte
+	i := len(buf)
+	for v > 0 {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	return string(buf[i:])
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMockSignerImplementsSigner exercises MockSigner through the Signer
+// interface so the contract (not just the concrete type) is covered.
+func TestMockSignerImplementsSigner(t *testing.T) {
+	var signer Signer = &MockSigner{
+		Addresses:  map[string]string{"44/60/0/0/0:EVM": "0xcanned"},
+		Signatures: map[string][]byte{"44/60/0/0/0:EVM": []byte("cannedsig")},
+	}
+	path := []uint32{1<<31 + 44, 1<<31 + 60, 1 << 31, 0, 0}
+
+	addr, err := signer.DeriveAddress(path, "EVM")
+	if err != nil {
+		t.Fatalf("DeriveAddress() error = %v", err)
+	}
+	if addr != "0xcanned" {
+		t.Errorf("DeriveAddress() = %q, want canned %q", addr, "0xcanned")
+	}
+
+	sig, err := signer.SignTx(path, []byte("rawtx"), "EVM")
+	if err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+	if !bytes.Equal(sig, []byte("cannedsig")) {
+		t.Errorf("SignTx() = %q, want canned %q", sig, "cannedsig")
+	}
+}
+
+// TestMockSignerFallsBackWithoutCannedValues checks the deterministic default
+// behavior used when a test doesn't care about specific addresses/signatures.
+func TestMockSignerFallsBackWithoutCannedValues(t *testing.T) {
+	signer := &MockSigner{}
+	path := []uint32{1<<31 + 44, 0}
+
+	addr, err := signer.DeriveAddress(path, "EVM")
+	if err != nil {
+		t.Fatalf("DeriveAddress() error = %v", err)
+	}
+	if addr != "0xmock44/0:EVM" {
+		t.Errorf("DeriveAddress() = %q, want %q", addr, "0xmock44/0:EVM")
+	}
+
+	sig, err := signer.SignTx(path, []byte("rawtx"), "EVM")
+	if err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+	want := "mocksig:44/0:EVM:rawtx"
+	if string(sig) != want {
+		t.Errorf("SignTx() = %q, want %q", sig, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Package slip10 implements SLIP-0010 key derivation for the ed25519 curve,
+// which only supports hardened derivation.
+package slip10
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+)
+
+// HardenedOffset marks an index as hardened, mirroring bip32.FirstHardenedChild.
+const HardenedOffset = uint32(0x80000000)
+
+// Key is a SLIP-0010 ed25519 extended key: a 32-byte private key seed plus
+// its 32-byte chain code.
+type Key struct {
+	Key       [32]byte
+	ChainCode [32]byte
+}
+
+// NewMasterKey derives the ed25519 master key from a BIP-39 seed per
+// SLIP-0010: I = HMAC-SHA512(key="ed25519 seed", data=seed).
+func NewMasterKey(seed []byte) (*Key, error) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+
+	key := &Key{}
+	copy(key.Key[:], i[:32])
+	copy(key.ChainCode[:], i[32:])
+	return key, nil
+}
+
+// NewChildKey derives the hardened child at the given index. SLIP-0010
+// ed25519 only defines hardened derivation, so index must already include
+// the hardened offset; non-hardened indices are rejected rather than
+// silently hardened.
+func NewChildKey(parent *Key, index uint32) (*Key, error) {
+	if index < HardenedOffset {
+		return nil, fmt.Errorf("slip10: ed25519 only supports hardened derivation, index %d is not hardened", index)
+	}
+
+	var data [37]byte
+	data[0] = 0x00
+	copy(data[1:33], parent.Key[:])
+	binary.BigEndian.PutUint32(data[33:], index)
+
+	mac := hmac.New(sha512.New, parent.ChainCode[:])
+	mac.Write(data[:])
+	i := mac.Sum(nil)
+
+	child := &Key{}
+	copy(child.Key[:], i[:32])
+	copy(child.ChainCode[:], i[32:])
+	return child, nil
+}
+
+// DerivePath walks a sequence of indices from the master key. Every index
+// must already include HardenedOffset, as SLIP-0010 ed25519 requires; a
+// non-hardened index makes it return an error.
+func DerivePath(master *Key, path []uint32) (*Key, error) {
+	key := master
+	for _, index := range path {
+		child, err := NewChildKey(key, index)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving slip10 child key: %v", err)
+		}
+		key = child
+	}
+	return key, nil
+}
@@ -0,0 +1,82 @@
+package slip10
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// slip10Vectors are derived directly from the SLIP-0010 ed25519 derivation
+// algorithm (HMAC-SHA512 master/child formulas) over the standard BIP-32
+// test seed, computed independently of this package to guard against a
+// regression in NewMasterKey/NewChildKey.
+var slip10Vectors = []struct {
+	name      string
+	seed      string
+	path      []uint32
+	key       string
+	chainCode string
+}{
+	{
+		name:      "m",
+		seed:      "000102030405060708090a0b0c0d0e0f",
+		path:      nil,
+		key:       "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		chainCode: "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+	},
+	{
+		name:      "m/0'",
+		seed:      "000102030405060708090a0b0c0d0e0f",
+		path:      []uint32{HardenedOffset},
+		key:       "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		chainCode: "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+	},
+	{
+		name:      "m/0'/1'",
+		seed:      "000102030405060708090a0b0c0d0e0f",
+		path:      []uint32{HardenedOffset, HardenedOffset + 1},
+		key:       "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		chainCode: "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+	},
+}
+
+func TestDerivePath(t *testing.T) {
+	for _, v := range slip10Vectors {
+		t.Run(v.name, func(t *testing.T) {
+			seed, err := hex.DecodeString(v.seed)
+			if err != nil {
+				t.Fatalf("invalid seed fixture: %v", err)
+			}
+			master, err := NewMasterKey(seed)
+			if err != nil {
+				t.Fatalf("NewMasterKey() error = %v", err)
+			}
+
+			key, err := DerivePath(master, v.path)
+			if err != nil {
+				t.Fatalf("DerivePath() error = %v", err)
+			}
+			if got := hex.EncodeToString(key.Key[:]); got != v.key {
+				t.Errorf("Key = %s, want %s", got, v.key)
+			}
+			if got := hex.EncodeToString(key.ChainCode[:]); got != v.chainCode {
+				t.Errorf("ChainCode = %s, want %s", got, v.chainCode)
+			}
+		})
+	}
+}
+
+func TestNewChildKeyRejectsNonHardened(t *testing.T) {
+	master, err := NewMasterKey([]byte("00000000000000000000000000000000"))
+	if err != nil {
+		t.Fatalf("NewMasterKey() error = %v", err)
+	}
+	if _, err := NewChildKey(master, 0); err == nil {
+		t.Error("NewChildKey() with a non-hardened index should return an error")
+	}
+	if _, err := NewChildKey(master, HardenedOffset-1); err == nil {
+		t.Error("NewChildKey() with a non-hardened index should return an error")
+	}
+	if _, err := NewChildKey(master, HardenedOffset); err != nil {
+		t.Errorf("NewChildKey() with a hardened index should succeed, got error = %v", err)
+	}
+}
@@ -3,22 +3,49 @@ package utils
 import (
 	"crypto/ecdsa"
 	"crypto/sha256"
+	"encoding/hex"
 	"log"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/ethereum/go-ethereum/crypto"
 	mrtronBase58 "github.com/mr-tron/base58"
 	"github.com/tyler-smith/go-bip32"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
 )
 
+// Wallet holds the data generated for a single derived account. PrivateKey
+// and PublicKey are omitted from the JSON output when empty, which is the
+// case for wallets sourced from a hardware signer.
+type Wallet struct {
+	PrivateKey string `json:"PrivateKey,omitempty"`
+	PublicKey  string `json:"PublicKey,omitempty"`
+	Address    string `json:"Address"`
+}
+
 // AddressConversion converts the child key to an address for different networks.
 func AddressConversion(childKey *bip32.Key, network string) string {
+	return AddressConversionForPurpose(childKey, network, 44)
+}
+
+// AddressConversionForPurpose converts the child key to an address for
+// different networks, taking the BIP-44/49/84 purpose into account for
+// Bitcoin so that BIP-49 (P2SH-P2WPKH) and BIP-84 (native SegWit) wallets get
+// their corresponding address format.
+func AddressConversionForPurpose(childKey *bip32.Key, network string, purpose uint32) string {
 	switch network {
 	case "EVM":
 		return generateEVMAddress(childKey)
 	case "BTC":
-		return generateBTCAddress(childKey)
+		switch purpose {
+		case 49:
+			return generateBIP49Address(childKey)
+		case 84:
+			return generateBIP84Address(childKey)
+		default:
+			return generateBTCAddress(childKey)
+		}
 	case "TRX":
 		return generateTRXAddress(childKey)
 	case "ERC":
@@ -50,6 +77,32 @@ func generateBTCAddress(childKey *bip32.Key) string {
 	return base58.Encode(fullPayload)
 }
 
+// generateBIP49Address generates a BIP-49 P2SH-wrapped-SegWit Bitcoin address
+// ("3..."), which is the hash160 of a 0x0014 || hash160(pubkey) redeem script
+// under the P2SH version byte (0x05).
+func generateBIP49Address(childKey *bip32.Key) string {
+	pubKeyHash := hash160(childKey.PublicKey().Key)
+	redeemScript := append([]byte{0x00, 0x14}, pubKeyHash...)
+	scriptHash := hash160(redeemScript)
+
+	versionedPayload := append([]byte{0x05}, scriptHash...)
+	fullPayload := append(versionedPayload, computeChecksum(versionedPayload)...)
+	return base58.Encode(fullPayload)
+}
+
+// generateBIP84Address generates a BIP-84 native SegWit Bitcoin address
+// ("bc1...") by bech32-encoding the hash160 of the public key as a version-0
+// witness program.
+func generateBIP84Address(childKey *bip32.Key) string {
+	pubKeyHash := hash160(childKey.PublicKey().Key)
+	address, err := encodeSegwitAddress("bc", pubKeyHash)
+	if err != nil {
+		log.Printf("error encoding segwit address: %v", err)
+		return ""
+	}
+	return address
+}
+
 // generateERCAddress generates a Europecoin address from the child key.
 func generateERCAddress(childKey *bip32.Key) string {
 	pubKeyHash := hash160(childKey.PublicKey().Key)
@@ -84,6 +137,47 @@ func generateTRXAddress(childKey *bip32.Key) string {
 	return mrtronBase58.Encode(fullPayload)
 }
 
+// AddressConversionEd25519 converts an ed25519 public key to an address for
+// the SLIP-0010-derived networks.
+func AddressConversionEd25519(pubKey []byte, network string) string {
+	switch network {
+	case "SOL":
+		return generateSolanaAddress(pubKey)
+	case "SUI":
+		return generateSuiAddress(pubKey)
+	case "APT":
+		return generateAptosAddress(pubKey)
+	default:
+		log.Printf("Unsupported network: %s", network)
+		return ""
+	}
+}
+
+// generateSolanaAddress encodes an ed25519 public key as a base58 Solana address.
+func generateSolanaAddress(pubKey []byte) string {
+	return base58.Encode(pubKey)
+}
+
+// generateSuiAddress derives a Sui address as the hex-encoded, 0x-prefixed
+// BLAKE2b-256 hash of a single-byte ed25519 scheme flag followed by the
+// public key.
+func generateSuiAddress(pubKey []byte) string {
+	const ed25519Flag = 0x00
+	data := append([]byte{ed25519Flag}, pubKey...)
+	hash := blake2b.Sum256(data)
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+// generateAptosAddress derives an Aptos address as the hex-encoded, 0x-prefixed
+// SHA3-256 hash of the public key followed by the single-byte ed25519 scheme
+// identifier.
+func generateAptosAddress(pubKey []byte) string {
+	const ed25519Scheme = 0x00
+	data := append(append([]byte{}, pubKey...), ed25519Scheme)
+	hash := sha3.Sum256(data)
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
 // computeChecksum computes a double SHA-256 checksum for the provided data.
 func computeChecksum(data []byte) []byte {
 	firstHash := sha256.Sum256(data)
@@ -113,7 +207,24 @@ func GetCoinType(network string) uint32 {
 		return 151
 	case "TRX":
 		return 195
+	case "SOL":
+		return 501
+	case "SUI":
+		return 784
+	case "APT":
+		return 637
 	default:
 		return 60
 	}
 }
+
+// IsEd25519Network reports whether a network derives keys via SLIP-0010
+// ed25519 rather than BIP-32 secp256k1.
+func IsEd25519Network(network string) bool {
+	switch network {
+	case "SOL", "SUI", "APT":
+		return true
+	default:
+		return false
+	}
+}
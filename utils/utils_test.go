@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// TestAddressConversionForPurposeBIP49BIP84 uses the standard "abandon...
+// about" BIP-39 test mnemonic to reproduce the reference addresses from the
+// BIP-49 and BIP-84 specs for account 0's first receiving key.
+func TestAddressConversionForPurposeBIP49BIP84(t *testing.T) {
+	seed := bip39.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+
+	tests := []struct {
+		name    string
+		purpose uint32
+		path    string
+		want    string
+	}{
+		{
+			name:    "bip49 P2SH-P2WPKH",
+			purpose: 49,
+			path:    "m/49'/0'/0'/0/0",
+			want:    "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		},
+		{
+			name:    "bip84 native segwit",
+			purpose: 84,
+			path:    "m/84'/0'/0'/0/0",
+			want:    "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indices, err := ParseDerivationPath(tt.path)
+			if err != nil {
+				t.Fatalf("ParseDerivationPath(%q) error = %v", tt.path, err)
+			}
+
+			key, err := bip32.NewMasterKey(seed)
+			if err != nil {
+				t.Fatalf("bip32.NewMasterKey() error = %v", err)
+			}
+			for _, index := range indices {
+				key, err = key.NewChildKey(index)
+				if err != nil {
+					t.Fatalf("NewChildKey(%d) error = %v", index, err)
+				}
+			}
+
+			got := AddressConversionForPurpose(key, "BTC", tt.purpose)
+			if got != tt.want {
+				t.Errorf("AddressConversionForPurpose() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeDevice is a minimal hid.Device double: Write records every packet sent,
+// and Read serves back a pre-built queue of 64-byte packets (or readErr once
+// the queue is exhausted).
+type fakeDevice struct {
+	writes  [][]byte
+	reads   [][]byte
+	readIdx int
+	readErr error
+}
+
+func (d *fakeDevice) Close() error { return nil }
+
+func (d *fakeDevice) Write(b []byte) (int, error) {
+	d.writes = append(d.writes, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func (d *fakeDevice) Read(b []byte) (int, error) {
+	if d.readIdx >= len(d.reads) {
+		if d.readErr != nil {
+			return 0, d.readErr
+		}
+		return 0, errors.New("fakeDevice: read queue exhausted")
+	}
+	n := copy(b, d.reads[d.readIdx])
+	d.readIdx++
+	return n, nil
+}
+
+func (d *fakeDevice) ReadTimeout(b []byte, timeout int) (int, error) { return d.Read(b) }
+func (d *fakeDevice) GetFeatureReport(b []byte) (int, error)         { return 0, nil }
+func (d *fakeDevice) SendFeatureReport(b []byte) (int, error)        { return 0, nil }
+
+// buildReplyPackets frames payload into the 64-byte Ledger USB HID packets
+// exchange() expects to read back, mirroring the layout documented on
+// exchange(): 2-byte channel id, 1-byte APDU tag, 2-byte sequence number,
+// then (first packet only) a 2-byte total length before the chunk.
+func buildReplyPackets(payload []byte) [][]byte {
+	var packets [][]byte
+	remaining := payload
+	for seq := 0; ; seq++ {
+		packet := []byte{0x01, 0x01, hidTagAPDU, 0x00, 0x00}
+		binary.BigEndian.PutUint16(packet[3:], uint16(seq))
+		if seq == 0 {
+			lenBuf := make([]byte, 2)
+			binary.BigEndian.PutUint16(lenBuf, uint16(len(payload)))
+			packet = append(packet, lenBuf...)
+		}
+		space := hidPacketSize - len(packet)
+		if len(remaining) > space {
+			packet = append(packet, remaining[:space]...)
+			remaining = remaining[space:]
+		} else {
+			packet = append(packet, remaining...)
+			remaining = nil
+		}
+		packet = append(packet, make([]byte, hidPacketSize-len(packet))...)
+		packets = append(packets, packet)
+		if len(remaining) == 0 {
+			return packets
+		}
+	}
+}
+
+func TestExchangeSingleChunkRoundTrip(t *testing.T) {
+	apdu := []byte{claEthereum, insGetPublicKey, p1NoConfirmation, p2NoChainCode}
+	want := []byte{0x01, 0x02, 0x03}
+	device := &fakeDevice{reads: buildReplyPackets(append(append([]byte(nil), want...), 0x90, 0x00))}
+
+	s := &Signer{device: device}
+	got, err := s.exchange(apdu)
+	if err != nil {
+		t.Fatalf("exchange() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exchange() = %x, want %x", got, want)
+	}
+
+	if len(device.writes) != 1 {
+		t.Fatalf("exchange() wrote %d packets, want 1", len(device.writes))
+	}
+	sent := device.writes[0]
+	if len(sent) != hidPacketSize {
+		t.Fatalf("sent packet length = %d, want %d", len(sent), hidPacketSize)
+	}
+	if sent[0] != 0x01 || sent[1] != 0x01 || sent[2] != hidTagAPDU {
+		t.Errorf("sent packet header = %x, want channel 0101 tag %02x", sent[:3], hidTagAPDU)
+	}
+	if seq := binary.BigEndian.Uint16(sent[3:5]); seq != 0 {
+		t.Errorf("sent packet sequence = %d, want 0", seq)
+	}
+	// sent[5:7] is the APDU length, sent[7:] is the APDU itself.
+	if apduLen := binary.BigEndian.Uint16(sent[5:7]); int(apduLen) != len(apdu) {
+		t.Errorf("sent apdu length = %d, want %d", apduLen, len(apdu))
+	}
+	if !reflect.DeepEqual(sent[7:7+len(apdu)], apdu) {
+		t.Errorf("sent apdu = %x, want %x", sent[7:7+len(apdu)], apdu)
+	}
+}
+
+func TestExchangeMultiPacketFraming(t *testing.T) {
+	// A payload bigger than a single 64-byte packet's space on both sides
+	// forces exchange() to split the outgoing APDU and reassemble a
+	// multi-packet reply.
+	apdu := make([]byte, 120)
+	for i := range apdu {
+		apdu[i] = byte(i)
+	}
+	want := make([]byte, 100)
+	for i := range want {
+		want[i] = byte(200 + i)
+	}
+	device := &fakeDevice{reads: buildReplyPackets(append(append([]byte(nil), want...), 0x90, 0x00))}
+
+	s := &Signer{device: device}
+	got, err := s.exchange(apdu)
+	if err != nil {
+		t.Fatalf("exchange() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exchange() = %x, want %x", got, want)
+	}
+
+	if len(device.writes) < 2 {
+		t.Fatalf("exchange() wrote %d packets for a %d-byte apdu, want at least 2", len(device.writes), len(apdu))
+	}
+	for i, packet := range device.writes {
+		if len(packet) != hidPacketSize {
+			t.Errorf("packet %d length = %d, want %d", i, len(packet), hidPacketSize)
+		}
+		if seq := binary.BigEndian.Uint16(packet[3:5]); int(seq) != i {
+			t.Errorf("packet %d sequence = %d, want %d", i, seq, i)
+		}
+	}
+}
+
+func TestExchangeRejectsWrongResponseHeader(t *testing.T) {
+	bad := make([]byte, hidPacketSize)
+	bad[0], bad[1], bad[2] = 0x01, 0x01, 0x02 // wrong tag (ping, not APDU)
+	device := &fakeDevice{reads: [][]byte{bad}}
+
+	s := &Signer{device: device}
+	if _, err := s.exchange([]byte{claEthereum, insGetPublicKey, 0, 0}); err == nil {
+		t.Error("exchange() expected an error for a malformed response header, got none")
+	}
+}
+
+func TestExchangePropagatesReadError(t *testing.T) {
+	boom := errors.New("device unplugged")
+	device := &fakeDevice{readErr: boom}
+
+	s := &Signer{device: device}
+	if _, err := s.exchange([]byte{claEthereum, insGetPublicKey, 0, 0}); err == nil {
+		t.Error("exchange() expected an error when the device read fails, got none")
+	}
+}
+
+func TestExchangeRejectsTruncatedFinalReply(t *testing.T) {
+	// A reply shorter than the 2-byte status word trailer is malformed even
+	// though the framing itself parsed fine.
+	device := &fakeDevice{reads: buildReplyPackets([]byte{0x01})}
+
+	s := &Signer{device: device}
+	if _, err := s.exchange([]byte{claEthereum, insGetPublicKey, 0, 0}); err == nil {
+		t.Error("exchange() expected an error for a truncated final reply, got none")
+	}
+}
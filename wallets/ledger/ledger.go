@@ -0,0 +1,178 @@
+// Package ledger implements utils.Signer against a connected Ledger hardware
+// wallet, talking the Ethereum app APDU protocol over the documented USB HID
+// transport.
+//
+// Only the "EVM" network is supported: DeriveAddress and SignTx talk to the
+// Ethereum app and reject every other network. The Bitcoin app is not
+// supported.
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/karalabe/hid"
+
+	"multiNetworkWalletGenerator/wallets/ledger/usbwallet"
+)
+
+const (
+	claEthereum      = 0xe0
+	insGetPublicKey  = 0x02
+	insSignTx        = 0x04
+	p1NoConfirmation = 0x00
+	p2NoChainCode    = 0x00
+
+	// hidPacketSize is the fixed USB HID report size Ledger devices use.
+	hidPacketSize = 64
+	// hidChannelID is the communication channel id. It is not used to
+	// multiplex anything, but must be 0x0101 per the Ledger transport spec.
+	hidChannelID = 0x0101
+	// hidTagAPDU marks a packet as carrying a standard APDU payload.
+	hidTagAPDU = 0x05
+)
+
+// Signer drives a Ledger device over USB HID and implements utils.Signer.
+type Signer struct {
+	device hid.Device
+}
+
+// New opens the first connected Ledger device and returns a Signer for it.
+func New() (*Signer, error) {
+	device, err := usbwallet.NewHub().OpenFirst()
+	if err != nil {
+		return nil, fmt.Errorf("error opening ledger: %v", err)
+	}
+	return &Signer{device: device}, nil
+}
+
+// Close releases the underlying HID device.
+func (s *Signer) Close() error {
+	return s.device.Close()
+}
+
+// DeriveAddress asks the Ethereum app for the address at path via GET_PUBLIC_KEY.
+// Only the "EVM" network is currently supported; other networks require the
+// corresponding Ledger app and are rejected.
+func (s *Signer) DeriveAddress(path []uint32, network string) (string, error) {
+	if network != "EVM" {
+		return "", fmt.Errorf("unsupported ledger network: %s", network)
+	}
+
+	payload := encodePath(path)
+	apdu := append([]byte{claEthereum, insGetPublicKey, p1NoConfirmation, p2NoChainCode, byte(len(payload))}, payload...)
+	resp, err := s.exchange(apdu)
+	if err != nil {
+		return "", fmt.Errorf("error deriving ledger address: %v", err)
+	}
+	return parseEthAddress(resp)
+}
+
+// SignTx asks the Ethereum app to sign rawTx at path via SIGN_TX.
+func (s *Signer) SignTx(path []uint32, rawTx []byte, network string) ([]byte, error) {
+	if network != "EVM" {
+		return nil, fmt.Errorf("unsupported ledger network: %s", network)
+	}
+
+	payload := append(encodePath(path), rawTx...)
+	apdu := append([]byte{claEthereum, insSignTx, p1NoConfirmation, p2NoChainCode, byte(len(payload))}, payload...)
+	resp, err := s.exchange(apdu)
+	if err != nil {
+		return nil, fmt.Errorf("error signing ledger tx: %v", err)
+	}
+	return resp, nil
+}
+
+// exchange sends apdu to the device over the Ledger USB HID transport and
+// returns its response. The transport frames the APDU with a 2-byte channel
+// id, a 1-byte command tag, and a 2-byte sequence number, prefixes the first
+// packet's data with the 2-byte total APDU length, then splits it into
+// fixed-size 64-byte packets; the reply is reassembled the same way. See
+// go-ethereum's accounts/usbwallet/ledger.go (ledgerExchange) for the
+// reference implementation this mirrors.
+func (s *Signer) exchange(apdu []byte) ([]byte, error) {
+	header := []byte{hidChannelID >> 8, hidChannelID & 0xff, hidTagAPDU, 0x00, 0x00}
+	space := hidPacketSize - len(header)
+
+	payload := make([]byte, 2, 2+len(apdu))
+	binary.BigEndian.PutUint16(payload, uint16(len(apdu)))
+	payload = append(payload, apdu...)
+
+	for seq := 0; len(payload) > 0; seq++ {
+		packet := append([]byte(nil), header...)
+		binary.BigEndian.PutUint16(packet[3:], uint16(seq))
+		if len(payload) > space {
+			packet = append(packet, payload[:space]...)
+			payload = payload[space:]
+		} else {
+			packet = append(packet, payload...)
+			payload = nil
+		}
+		packet = append(packet, make([]byte, hidPacketSize-len(packet))...)
+		if _, err := s.device.Write(packet); err != nil {
+			return nil, fmt.Errorf("error writing apdu: %v", err)
+		}
+	}
+
+	var reply []byte
+	packet := make([]byte, hidPacketSize)
+	for {
+		if _, err := io.ReadFull(s.device, packet); err != nil {
+			return nil, fmt.Errorf("error reading apdu response: %v", err)
+		}
+		if packet[0] != header[0] || packet[1] != header[1] || packet[2] != hidTagAPDU {
+			return nil, fmt.Errorf("unexpected apdu response header")
+		}
+
+		var chunk []byte
+		if packet[3] == 0x00 && packet[4] == 0x00 {
+			reply = make([]byte, 0, int(binary.BigEndian.Uint16(packet[5:7])))
+			chunk = packet[7:]
+		} else {
+			chunk = packet[5:]
+		}
+		if left := cap(reply) - len(reply); left > len(chunk) {
+			reply = append(reply, chunk...)
+		} else {
+			reply = append(reply, chunk[:left]...)
+			break
+		}
+	}
+	// Last 2 bytes are the APDU status word (0x9000 on success); callers
+	// parse the response payload, not the status word.
+	if len(reply) < 2 {
+		return nil, fmt.Errorf("malformed apdu response")
+	}
+	return reply[:len(reply)-2], nil
+}
+
+// encodePath renders a BIP-32 path as the length-prefixed big-endian format
+// the Ledger Ethereum app expects.
+func encodePath(path []uint32) []byte {
+	out := []byte{byte(len(path))}
+	for _, index := range path {
+		var buf [4]byte
+		binary.BigEndian.PutUint32(buf[:], index)
+		out = append(out, buf[:]...)
+	}
+	return out
+}
+
+// parseEthAddress extracts the 0x-prefixed hex address from a GET_PUBLIC_KEY
+// response, which is laid out as pubKeyLen|pubKey|addrLen|addr|chainCode.
+func parseEthAddress(resp []byte) (string, error) {
+	if len(resp) < 1 {
+		return "", fmt.Errorf("empty ledger response")
+	}
+	pubKeyLen := int(resp[0])
+	if len(resp) < 1+pubKeyLen+1 {
+		return "", fmt.Errorf("malformed ledger response")
+	}
+	addrLen := int(resp[1+pubKeyLen])
+	addrOffset := 1 + pubKeyLen + 1
+	if len(resp) < addrOffset+addrLen {
+		return "", fmt.Errorf("malformed ledger response")
+	}
+	return "0x" + string(resp[addrOffset:addrOffset+addrLen]), nil
+}
@@ -0,0 +1,63 @@
+package ledger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+func TestEncodePath(t *testing.T) {
+	h := bip32.FirstHardenedChild
+
+	path := []uint32{h + 44, h + 60, h, 0, 0}
+	got := encodePath(path)
+	want := []byte{
+		0x05,
+		0x80, 0x00, 0x00, 0x2c,
+		0x80, 0x00, 0x00, 0x3c,
+		0x80, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encodePath(%v) = %x, want %x", path, got, want)
+	}
+}
+
+func TestParseEthAddress(t *testing.T) {
+	pubKey := make([]byte, 65)
+	addr := "9858EfFD232B4033E47d90003D41EC34EcaEda1"
+
+	resp := append([]byte{byte(len(pubKey))}, pubKey...)
+	resp = append(resp, byte(len(addr)))
+	resp = append(resp, []byte(addr)...)
+
+	got, err := parseEthAddress(resp)
+	if err != nil {
+		t.Fatalf("parseEthAddress() error = %v", err)
+	}
+	want := "0x" + addr
+	if got != want {
+		t.Errorf("parseEthAddress() = %q, want %q", got, want)
+	}
+}
+
+func TestParseEthAddressMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		resp []byte
+	}{
+		{name: "empty response", resp: nil},
+		{name: "truncated public key", resp: []byte{65, 1, 2, 3}},
+		{name: "truncated address", resp: []byte{0, 40, 'a', 'b'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseEthAddress(tt.resp); err == nil {
+				t.Errorf("parseEthAddress(%x) expected an error, got none", tt.resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package usbwallet enumerates USB HID hardware wallets.
+package usbwallet
+
+import (
+	"fmt"
+
+	"github.com/karalabe/hid"
+)
+
+// ledgerUSBVendorID is the USB vendor ID assigned to Ledger devices.
+const ledgerUSBVendorID = 0x2c97
+
+// Hub enumerates and opens connected hardware wallet devices.
+type Hub struct{}
+
+// NewHub creates a Hub ready to enumerate devices.
+func NewHub() *Hub {
+	return &Hub{}
+}
+
+// Devices returns the HID device infos for every connected Ledger device.
+func (h *Hub) Devices() ([]hid.DeviceInfo, error) {
+	return hid.Enumerate(ledgerUSBVendorID, 0)
+}
+
+// OpenFirst opens the first connected Ledger device.
+func (h *Hub) OpenFirst() (hid.Device, error) {
+	devices, err := h.Devices()
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no ledger device found")
+	}
+	device, err := devices[0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening ledger device: %v", err)
+	}
+	return device, nil
+}